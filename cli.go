@@ -0,0 +1,199 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/demouth/orenoagent-cli/store"
+)
+
+// toolServerFlag collects repeated --tool-server host:port flags.
+type toolServerFlag []string
+
+func (f *toolServerFlag) String() string { return strings.Join(*f, ",") }
+
+func (f *toolServerFlag) Set(addr string) error {
+	*f = append(*f, addr)
+	return nil
+}
+
+// loadToolServerConfig reads one tool-server address per line from path,
+// ignoring blank lines and lines starting with "#".
+func loadToolServerConfig(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("tool-config %s: %w", path, err)
+	}
+	defer f.Close()
+
+	var addrs []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		addrs = append(addrs, line)
+	}
+	return addrs, scanner.Err()
+}
+
+// storePath returns the path to the SQLite database, honoring
+// $ORENOAGENT_HOME if set and defaulting to ~/.orenoagent otherwise.
+func storePath() (string, error) {
+	home := os.Getenv("ORENOAGENT_HOME")
+	if home == "" {
+		dir, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("store path: %w", err)
+		}
+		home = filepath.Join(dir, ".orenoagent")
+	}
+	if err := os.MkdirAll(home, 0o755); err != nil {
+		return "", fmt.Errorf("store path: %w", err)
+	}
+	return filepath.Join(home, "conversations.db"), nil
+}
+
+// newConversationID returns a process-unique, lexically-sortable ID for a
+// new conversation.
+func newConversationID() string {
+	return "c" + strconv.FormatInt(time.Now().UnixNano(), 36)
+}
+
+// runCLI dispatches the `new`/`list`/`resume`/`rm`/`export` subcommands.
+// It returns (conversationID, handled) -- handled is true when the
+// subcommand ran to completion and the TUI should not start.
+func runCLI(db *store.Store, args []string) (string, bool) {
+	if len(args) == 0 {
+		return newConversation(db, ""), false
+	}
+
+	switch args[0] {
+	case "new":
+		fs := flag.NewFlagSet("new", flag.ExitOnError)
+		title := fs.String("title", "", "conversation title")
+		fs.Parse(args[1:])
+		return newConversation(db, *title), false
+
+	case "list":
+		cmdList(db)
+		return "", true
+
+	case "resume":
+		if len(args) < 2 {
+			fmt.Fprintln(os.Stderr, "usage: orenoagent-cli resume <id>")
+			os.Exit(1)
+		}
+		if _, err := db.Conversation(args[1]); err != nil {
+			fmt.Fprintf(os.Stderr, "orenoagent-cli: no such conversation %q\n", args[1])
+			os.Exit(1)
+		}
+		return args[1], false
+
+	case "rm":
+		if len(args) < 2 {
+			fmt.Fprintln(os.Stderr, "usage: orenoagent-cli rm <id>")
+			os.Exit(1)
+		}
+		cmdRm(db, args[1])
+		return "", true
+
+	case "export":
+		if len(args) < 2 {
+			fmt.Fprintln(os.Stderr, "usage: orenoagent-cli export <id> --format md|json")
+			os.Exit(1)
+		}
+		fs := flag.NewFlagSet("export", flag.ExitOnError)
+		format := fs.String("format", "md", "export format: md or json")
+		fs.Parse(args[2:])
+		cmdExport(db, args[1], *format)
+		return "", true
+
+	default:
+		return newConversation(db, ""), false
+	}
+}
+
+func newConversation(db *store.Store, title string) string {
+	id := newConversationID()
+	if title == "" {
+		title = "conversation " + id
+	}
+	if _, err := db.NewConversation(id, title); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	return id
+}
+
+func cmdList(db *store.Store) {
+	conversations, err := db.ListConversations()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	for _, c := range conversations {
+		fmt.Printf("%s\t%s\t%s\n", c.ID, c.UpdatedAt.Format(time.RFC3339), c.Title)
+	}
+}
+
+func cmdRm(db *store.Store, id string) {
+	if err := db.DeleteConversation(id); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func cmdExport(db *store.Store, id, format string) {
+	rows, err := db.LoadTurns(id)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	switch format {
+	case "json":
+		v, _ := json.MarshalIndent(rows, "", "  ")
+		fmt.Println(string(v))
+	case "md":
+		var b strings.Builder
+		for _, r := range rows {
+			b.WriteString("### " + strings.ToUpper(r.Role) + "\n\n" + r.Content + "\n\n")
+		}
+		fmt.Print(b.String())
+	default:
+		fmt.Fprintf(os.Stderr, "unknown format %q (want md or json)\n", format)
+		os.Exit(1)
+	}
+}
+
+// loadConversation replays a conversation's stored rows into a fresh turn
+// tree, returning the root, an ID-indexed lookup of every turn, and the
+// most recently stored turn (the tip to resume from).
+func loadConversation(db *store.Store, id string) (root *Turn, turns map[string]*Turn, active *Turn, err error) {
+	rows, err := db.LoadTurns(id)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	root = &Turn{ID: ""}
+	turns = map[string]*Turn{}
+	active = root
+	for _, r := range rows {
+		t := &Turn{ID: r.ID, ParentID: r.ParentID, Role: r.Role, Content: r.Content}
+		parent, ok := turns[t.ParentID]
+		if !ok {
+			parent = root
+		}
+		parent.Children = append(parent.Children, t)
+		turns[t.ID] = t
+		active = t
+	}
+	return root, turns, active, nil
+}
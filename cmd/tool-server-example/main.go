@@ -0,0 +1,83 @@
+// Command tool-server-example is a reference implementation of the
+// toolserver contract, offering a "currentTime" and an "echo" tool over gRPC.
+// Run it and point orenoagent-cli at it with --tool-server to confirm
+// the contract works before writing a real tool server.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"net"
+	"time"
+
+	"github.com/demouth/orenoagent-cli/toolserver"
+	"google.golang.org/grpc"
+)
+
+type service struct{}
+
+func (service) Describe(_ context.Context, _ *toolserver.DescribeRequest) (*toolserver.DescribeResponse, error) {
+	return &toolserver.DescribeResponse{
+		Tools: []toolserver.ToolSpec{
+			{
+				Name:        "currentTime",
+				Description: "Get the current date and time with timezone in a human-readable format.",
+			},
+			{
+				// Exercises ToolSpec.Parameters end-to-end: currentTime
+				// leaves it nil, so that path alone never proves the
+				// field actually survives the trip over the wire.
+				Name:        "echo",
+				Description: "Echo the given text back, unchanged.",
+				Parameters: map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"text": map[string]string{
+							"type":        "string",
+							"description": "Text to echo back.",
+						},
+					},
+					"required": []string{"text"},
+				},
+			},
+		},
+	}, nil
+}
+
+func (service) Invoke(_ context.Context, args *toolserver.InvokeRequest) (*toolserver.InvokeResponse, error) {
+	switch args.Name {
+	case "currentTime":
+		return &toolserver.InvokeResponse{Result: time.Now().Format(time.RFC3339)}, nil
+	case "echo":
+		var param struct {
+			Text string
+		}
+		if err := json.Unmarshal([]byte(args.ArgsJSON), &param); err != nil {
+			return nil, fmt.Errorf("tool-server-example: echo: %w", err)
+		}
+		return &toolserver.InvokeResponse{Result: param.Text}, nil
+	default:
+		return nil, fmt.Errorf("tool-server-example: unknown tool %q", args.Name)
+	}
+}
+
+func main() {
+	addr := flag.String("addr", ":9090", "address to listen on")
+	flag.Parse()
+
+	ln, err := net.Listen("tcp", *addr)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	s := grpc.NewServer()
+	toolserver.RegisterToolServerServer(s, service{})
+
+	log.Printf("tool-server-example listening on %s", *addr)
+	if err := s.Serve(ln); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/sahilm/fuzzy"
+)
+
+// Command is a single slash-command that the palette can dispatch. Run
+// receives the parsed argument tokens (the command name itself stripped).
+type Command struct {
+	Name string
+	Help string
+	Run  func(m *model, args []string) tea.Cmd
+}
+
+// commandRegistry holds the built-in slash-commands. Add new commands here;
+// the palette, fuzzy matching, and dispatch all drive off this slice.
+var commandRegistry = []Command{
+	{Name: "clear", Help: "Clear the conversation view", Run: runClear},
+	{Name: "save", Help: "save <path>  Save the transcript to a file", Run: runSave},
+	{Name: "load", Help: "load <path>  Load a transcript from a file", Run: runLoad},
+	{Name: "model", Help: "model <name>  Record a model name; the running agent's model does not change", Run: runModel},
+	{Name: "tool", Help: "tool enable|disable <name>  Record a tool as enabled or disabled; the agent's tool list does not change", Run: runTool},
+	{Name: "system", Help: "system <prompt>  Set the system prompt", Run: runSystem},
+	{Name: "retry", Help: "Re-ask the last question", Run: runRetry},
+	{Name: "branch", Help: "Jump to the parent turn to start a new branch", Run: runBranch},
+	{Name: "help", Help: "List available commands", Run: runHelp},
+}
+
+// filterCommands fuzzy-matches query (without the leading "/") against the
+// registry and returns matches ranked best-first. An empty query returns the
+// whole registry in its declared order.
+func filterCommands(query string) []Command {
+	if query == "" {
+		return commandRegistry
+	}
+	names := make([]string, len(commandRegistry))
+	for i, c := range commandRegistry {
+		names[i] = c.Name
+	}
+	matches := fuzzy.Find(query, names)
+	out := make([]Command, 0, len(matches))
+	for _, match := range matches {
+		out = append(out, commandRegistry[match.Index])
+	}
+	return out
+}
+
+// parseCommand splits "/name arg1 arg2" into its command name and args.
+// The leading "/" must already be present in raw.
+func parseCommand(raw string) (name string, args []string) {
+	fields := strings.Fields(strings.TrimPrefix(raw, "/"))
+	if len(fields) == 0 {
+		return "", nil
+	}
+	return fields[0], fields[1:]
+}
+
+func runClear(m *model, _ []string) tea.Cmd {
+	m.root.Children = nil
+	m.turns = map[string]*Turn{}
+	m.active = m.root
+	m.askTail = m.root
+	m.render()
+	return nil
+}
+
+func runSave(m *model, args []string) tea.Cmd {
+	if len(args) == 0 {
+		m.note("usage: /save <path>")
+		return nil
+	}
+	lines := make([]string, 0)
+	for _, t := range m.pathTo(m.active) {
+		lines = append(lines, t.styled())
+	}
+	if err := saveTranscript(args[0], lines); err != nil {
+		m.note(err.Error())
+		return nil
+	}
+	m.note("saved transcript to " + args[0])
+	return nil
+}
+
+func runLoad(m *model, args []string) tea.Cmd {
+	if len(args) == 0 {
+		m.note("usage: /load <path>")
+		return nil
+	}
+	lines, err := loadTranscript(args[0])
+	if err != nil {
+		m.note(err.Error())
+		return nil
+	}
+	for _, line := range lines {
+		m.active = m.addTurn(m.active, "raw", line)
+	}
+	m.askTail = m.active
+	m.render()
+	return nil
+}
+
+func runModel(m *model, args []string) tea.Cmd {
+	if len(args) == 0 {
+		m.note("usage: /model <name>")
+		return nil
+	}
+	m.modelName = args[0]
+	m.note("model set to " + args[0])
+	return nil
+}
+
+func runTool(m *model, args []string) tea.Cmd {
+	if len(args) != 2 || (args[0] != "enable" && args[0] != "disable") {
+		m.note("usage: /tool enable|disable <name>")
+		return nil
+	}
+	enabled := args[0] == "enable"
+	m.disabledTools[args[1]] = !enabled
+	state := "enabled"
+	if !enabled {
+		state = "disabled"
+	}
+	m.note(args[1] + " " + state)
+	return nil
+}
+
+func runSystem(m *model, args []string) tea.Cmd {
+	m.systemPrompt = strings.Join(args, " ")
+	m.note("system prompt updated")
+	return nil
+}
+
+func runRetry(m *model, _ []string) tea.Cmd {
+	if m.lastQuestion == "" {
+		m.note("nothing to retry")
+		return nil
+	}
+	m.askTail = m.active
+	m.ask(m.lastQuestion, m.active, program)
+	return nil
+}
+
+func runBranch(m *model, _ []string) tea.Cmd {
+	if m.active.ID == "" {
+		m.note("already at the start of the conversation")
+		return nil
+	}
+	m.active = m.parentOf(m.active)
+	m.render()
+	return nil
+}
+
+func runHelp(m *model, _ []string) tea.Cmd {
+	var b strings.Builder
+	for _, c := range commandRegistry {
+		b.WriteString("/" + c.Name + "  " + c.Help + "\n")
+	}
+	m.note(strings.TrimRight(b.String(), "\n"))
+	return nil
+}
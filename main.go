@@ -2,17 +2,28 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"log"
+	"os"
+	"strings"
+	"time"
 
+	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/textarea"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/demouth/orenoagent-cli/store"
+	"github.com/demouth/orenoagent-cli/toolregistry"
 	"github.com/demouth/orenoagent-go"
 	"github.com/openai/openai-go/v3"
 )
 
+// toolServerTimeout bounds how long a single remote tool call may take
+// before the registry gives up and reports a timeout to the model.
+const toolServerTimeout = 10 * time.Second
+
 const gap = "\n\n"
 
 type functionCallMessage struct {
@@ -44,37 +55,127 @@ func (m reasoningMessage) Content() string {
 	return s
 }
 
+type userMessage struct {
+	message string
+}
+
+func (m userMessage) Content() string {
+	return lipgloss.NewStyle().Background(lipgloss.Color("5")).Render(" You ") + " " + m.message
+}
+
 var program *tea.Program
 var agent *orenoagent.Agent
 var ctx context.Context
+var db *store.Store
+var conversationID string
 
 func main() {
+	fs := flag.NewFlagSet("orenoagent-cli", flag.ExitOnError)
+	var toolServers toolServerFlag
+	fs.Var(&toolServers, "tool-server", "address of an external tool server (host:port); repeatable")
+	toolConfig := fs.String("tool-config", "", "path to a config file listing tool server addresses, one per line")
+	fs.Parse(os.Args[1:])
+
+	if *toolConfig != "" {
+		addrs, err := loadToolServerConfig(*toolConfig)
+		if err != nil {
+			log.Fatal(err)
+		}
+		toolServers = append(toolServers, addrs...)
+	}
+
+	path, err := storePath()
+	if err != nil {
+		log.Fatal(err)
+	}
+	db, err = store.Open(path)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer db.Close()
+
+	id, handled := runCLI(db, fs.Args())
+	if handled {
+		return
+	}
+	conversationID = id
+
+	ctx = context.Background()
+
+	registry := toolregistry.New(toolServerTimeout)
+	for _, addr := range toolServers {
+		registry.AddServer(addr)
+	}
+	tools := registry.Tools(ctx, Tools)
+
 	model := initialModel()
 	program = tea.NewProgram(model)
 
 	client := openai.NewClient()
-	ctx = context.Background()
-	agent = orenoagent.NewAgent(client, Tools, true)
+	agent = orenoagent.NewAgent(client, tools, true)
 
 	if _, err := program.Run(); err != nil {
 		log.Fatal(err)
 	}
 }
 
-func ask(question string, p *tea.Program) {
+// tokenCount approximates usage for the store's tokens column. The
+// upstream agent client does not yet surface real token counts.
+func tokenCount(s string) int {
+	return len(strings.Fields(s))
+}
+
+// persistTurn writes t to the conversation store, logging rather than
+// failing the UI if the write does not succeed.
+func persistTurn(t *Turn) {
+	if db == nil || conversationID == "" {
+		return
+	}
+	row := store.Row{
+		ID:             t.ID,
+		ConversationID: conversationID,
+		ParentID:       t.ParentID,
+		Role:           t.Role,
+		Content:        t.Content,
+		Tokens:         tokenCount(t.Content),
+	}
+	if err := db.SaveTurn(row); err != nil {
+		log.Printf("store: %v", err)
+	}
+}
+
+// ask issues question to the agent, seeding it with the transcript of
+// parent's ancestor path so the reply continues the right branch of the
+// conversation tree rather than whatever the agent last saw.
+func (m *model) ask(question string, parent *Turn, p *tea.Program) {
+	prompt := question
+	if history := m.contextFor(parent); history != "" {
+		prompt = history + "User: " + question
+	}
+	if m.systemPrompt != "" {
+		prompt = "System: " + m.systemPrompt + "\n" + prompt
+	}
 
 	go func() {
-		results, _ := agent.Ask(ctx, question)
+		results, _ := agent.Ask(ctx, prompt)
 		for result := range results {
+			turnID := nextTurnID()
 			switch r := result.(type) {
 			case *orenoagent.MessageResult:
-				p.Send(answerMessage{message: r.String()})
+				streamText(p, turnID, r.String(),
+					func(id, fragment string) tea.Msg { return answerDelta{turnID: id, fragment: fragment} },
+					func(id string) tea.Msg { return answerDone{turnID: id} })
 			case *orenoagent.ReasoningResult:
-				p.Send(reasoningMessage{message: r.String()})
+				streamText(p, turnID, r.String(),
+					func(id, fragment string) tea.Msg { return reasoningDelta{turnID: id, fragment: fragment} },
+					func(id string) tea.Msg { return reasoningDone{turnID: id} })
 			case *orenoagent.FunctionCallResult:
-				p.Send(functionCallMessage{message: r.String()})
+				streamText(p, turnID, r.String(),
+					func(id, fragment string) tea.Msg { return functionCallDelta{turnID: id, fragment: fragment} },
+					func(id string) tea.Msg { return functionCallDone{turnID: id} })
 			}
 		}
+		p.Send(askDone{})
 	}()
 }
 
@@ -84,9 +185,25 @@ type (
 
 type model struct {
 	viewport viewport.Model
-	messages []string
 	textarea textarea.Model
+	spinner  spinner.Model
 	err      error
+
+	streaming bool
+
+	root    *Turn
+	turns   map[string]*Turn
+	active  *Turn
+	askTail *Turn
+
+	modelName     string
+	systemPrompt  string
+	lastQuestion  string
+	disabledTools map[string]bool
+
+	paletteOpen     bool
+	paletteMatches  []Command
+	paletteSelected int
 }
 
 func initialModel() model {
@@ -111,11 +228,28 @@ Type a message and press Enter to send.`)
 
 	ta.KeyMap.InsertNewline.SetEnabled(false)
 
+	sp := spinner.New()
+	sp.Spinner = spinner.Dot
+	sp.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("205"))
+
+	root, turns, active, err := loadConversation(db, conversationID)
+	if err != nil {
+		log.Printf("store: %v", err)
+		root = &Turn{ID: ""}
+		turns = map[string]*Turn{}
+		active = root
+	}
+
 	return model{
-		textarea: ta,
-		messages: []string{},
-		viewport: vp,
-		err:      nil,
+		textarea:      ta,
+		viewport:      vp,
+		spinner:       sp,
+		err:           nil,
+		root:          root,
+		turns:         turns,
+		active:        active,
+		askTail:       active,
+		disabledTools: map[string]bool{},
 	}
 }
 
@@ -127,65 +261,136 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var (
 		tiCmd tea.Cmd
 		vpCmd tea.Cmd
+		spCmd tea.Cmd
 	)
 
 	m.textarea, tiCmd = m.textarea.Update(msg)
 	m.viewport, vpCmd = m.viewport.Update(msg)
 
 	switch msg := msg.(type) {
+	case spinner.TickMsg:
+		if !m.streaming {
+			return m, nil
+		}
+		m.spinner, spCmd = m.spinner.Update(msg)
+		return m, spCmd
+	case askDone:
+		m.streaming = false
+		return m, nil
 	case tea.WindowSizeMsg:
 		m.viewport.Width = msg.Width
 		m.textarea.SetWidth(msg.Width)
 		m.viewport.Height = msg.Height - m.textarea.Height() - lipgloss.Height(gap)
 
-		if len(m.messages) > 0 {
+		if m.active != m.root {
 			// Wrap content before setting it.
 			m.render()
 		}
 		m.viewport.GotoBottom()
-	case answerMessage:
-		m.messages = append(m.messages, msg.Content())
+	case answerDelta:
+		t := m.ensureTurn(msg.turnID, m.askTail, "agent")
+		t.Content += msg.fragment
+		m.active = t
 		m.render()
 		m.viewport.GotoBottom()
 		return m, nil
-	case reasoningMessage:
-		m.messages = append(m.messages, msg.Content())
+	case reasoningDelta:
+		t := m.ensureTurn(msg.turnID, m.askTail, "reasoning")
+		t.Content += msg.fragment
+		m.active = t
 		m.render()
 		m.viewport.GotoBottom()
 		return m, nil
-	case functionCallMessage:
-		m.messages = append(m.messages, msg.Content())
+	case functionCallDelta:
+		t := m.ensureTurn(msg.turnID, m.askTail, "function")
+		t.Content += msg.fragment
+		m.active = t
 		m.render()
 		m.viewport.GotoBottom()
 		return m, nil
+	case answerDone:
+		m.finalizeTurn(msg.turnID)
+		return m, nil
+	case reasoningDone:
+		m.finalizeTurn(msg.turnID)
+		return m, nil
+	case functionCallDone:
+		m.finalizeTurn(msg.turnID)
+		return m, nil
 	case tea.KeyMsg:
+		if m.paletteOpen {
+			switch msg.Type {
+			case tea.KeyEsc:
+				m.closePalette()
+				return m, nil
+			case tea.KeyUp:
+				m.movePaletteSelection(-1)
+				return m, nil
+			case tea.KeyDown:
+				m.movePaletteSelection(1)
+				return m, nil
+			case tea.KeyTab:
+				m.completePaletteSelection()
+				return m, nil
+			case tea.KeyEnter:
+				m.runPaletteSelection()
+				return m, nil
+			}
+		}
+
 		switch msg.Type {
 		case tea.KeyCtrlJ:
 			m.textarea.InsertString("\n")
 		case tea.KeyCtrlC, tea.KeyEsc:
 			fmt.Println(m.textarea.Value())
 			return m, tea.Quit
+		case tea.KeyCtrlUp:
+			m.active = m.parentOf(m.active)
+			m.render()
+			m.viewport.GotoBottom()
+		case tea.KeyCtrlLeft:
+			m.moveToSibling(-1)
+			m.render()
+			m.viewport.GotoBottom()
+		case tea.KeyCtrlRight:
+			m.moveToSibling(1)
+			m.render()
+			m.viewport.GotoBottom()
 		case tea.KeyEnter:
 			message := m.textarea.Value()
-			m.messages = append(m.messages, lipgloss.NewStyle().Background(lipgloss.Color("5")).Render(" You ")+" "+message)
+			m.lastQuestion = message
+			parent := m.active
+			userTurn := m.addTurn(parent, "user", message)
+			m.active = userTurn
+			m.askTail = userTurn
 			m.render()
 			m.textarea.Reset()
-			ask(message, program)
+			m.ask(message, parent, program)
+			m.streaming = true
+			spCmd = m.spinner.Tick
 			m.viewport.GotoBottom()
 		}
 
+		m.updatePalette()
+
 	// We handle errors just like any other message
 	case errMsg:
 		m.err = msg
 		return m, nil
 	}
 
-	return m, tea.Batch(tiCmd, vpCmd)
+	return m, tea.Batch(tiCmd, vpCmd, spCmd)
 }
 
 func (m *model) render() {
 	var s string
-	for _, message := range m.messages {
+	for _, turn := range m.pathTo(m.active) {
+		content := turn.styled()
+		if index, total := m.siblingIndex(turn); total > 1 {
+			header := lipgloss.NewStyle().Foreground(lipgloss.Color("241")).
+				Render(fmt.Sprintf("[%d/%d]", index+1, total))
+			content = header + "\n" + content
+		}
 		s = s + lipgloss.NewStyle().
 			BorderStyle(lipgloss.NormalBorder()).
 			BorderBottom(true).
@@ -194,17 +399,100 @@ func (m *model) render() {
 			PaddingLeft(1).
 			PaddingRight(1).
 			Render(
-				lipgloss.NewStyle().Width(m.viewport.Width).Render(message),
+				lipgloss.NewStyle().Width(m.viewport.Width).Render(content),
 			)
 	}
 	m.viewport.SetContent(s)
 }
 
+// updatePalette opens or closes the command palette based on the textarea
+// contents and refreshes the fuzzy-matched command list.
+func (m *model) updatePalette() {
+	value := m.textarea.Value()
+	if !strings.HasPrefix(value, "/") || strings.Contains(value, "\n") {
+		m.closePalette()
+		return
+	}
+	name, _ := parseCommand(value)
+	m.paletteOpen = true
+	m.paletteMatches = filterCommands(name)
+	if m.paletteSelected >= len(m.paletteMatches) {
+		m.paletteSelected = 0
+	}
+}
+
+func (m *model) closePalette() {
+	m.paletteOpen = false
+	m.paletteMatches = nil
+	m.paletteSelected = 0
+}
+
+func (m *model) movePaletteSelection(delta int) {
+	if len(m.paletteMatches) == 0 {
+		return
+	}
+	m.paletteSelected = (m.paletteSelected + delta + len(m.paletteMatches)) % len(m.paletteMatches)
+}
+
+func (m *model) completePaletteSelection() {
+	if len(m.paletteMatches) == 0 {
+		return
+	}
+	m.textarea.SetValue("/" + m.paletteMatches[m.paletteSelected].Name + " ")
+	m.textarea.CursorEnd()
+}
+
+func (m *model) runPaletteSelection() {
+	if len(m.paletteMatches) == 0 {
+		m.closePalette()
+		return
+	}
+	cmd := m.paletteMatches[m.paletteSelected]
+	_, args := parseCommand(m.textarea.Value())
+	m.textarea.Reset()
+	m.closePalette()
+	cmd.Run(m, args)
+	m.render()
+	m.viewport.GotoBottom()
+}
+
+// paletteView renders the floating fuzzy-match list shown above the input
+// while a slash-command is being typed.
+func (m model) paletteView() string {
+	if !m.paletteOpen || len(m.paletteMatches) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	for i, c := range m.paletteMatches {
+		line := "/" + c.Name + "  " + c.Help
+		style := lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
+		if i == m.paletteSelected {
+			style = lipgloss.NewStyle().Background(lipgloss.Color("5")).Foreground(lipgloss.Color("0"))
+		}
+		b.WriteString(style.Render(line) + "\n")
+	}
+	return lipgloss.NewStyle().
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderForeground(lipgloss.Color("241")).
+		Render(strings.TrimRight(b.String(), "\n"))
+}
+
+// typingIndicator renders a spinner next to a status line while a turn is
+// mid-stream, and is omitted once the ask cycle finishes.
+func (m model) typingIndicator() string {
+	if !m.streaming {
+		return ""
+	}
+	return m.spinner.View() + " thinking...\n"
+}
+
 func (m model) View() string {
 	return fmt.Sprintf(
-		"%s%s%s",
+		"%s%s%s%s%s",
 		m.viewport.View(),
 		gap,
+		m.typingIndicator(),
+		m.paletteView(),
 		m.textarea.View(),
 	)
 }
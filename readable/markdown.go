@@ -0,0 +1,255 @@
+package readable
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// ToMarkdown serializes an extracted article to Markdown, preserving
+// headings, lists, code fences, blockquotes, and tables.
+func ToMarkdown(a *Article) string {
+	var b strings.Builder
+	if a.Title != "" {
+		b.WriteString("# " + a.Title + "\n\n")
+	}
+	writeBlock(&b, a.Root)
+	return strings.TrimSpace(b.String()) + "\n"
+}
+
+// ToText serializes an extracted article to plain text: just the visible
+// words, whitespace-normalized.
+func ToText(a *Article) string {
+	var b strings.Builder
+	writeText(&b, a.Root)
+	return strings.TrimSpace(collapseBlankLines(b.String()))
+}
+
+func writeBlock(b *strings.Builder, n *html.Node) {
+	if n.Type == html.ElementNode {
+		switch n.DataAtom {
+		case atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6:
+			level := int(n.DataAtom - atom.H1 + 1)
+			b.WriteString(strings.Repeat("#", level) + " " + inlineText(n) + "\n\n")
+			return
+		case atom.P:
+			b.WriteString(inlineText(n) + "\n\n")
+			return
+		case atom.Blockquote:
+			for _, line := range strings.Split(strings.TrimSpace(inlineText(n)), "\n") {
+				b.WriteString("> " + line + "\n")
+			}
+			b.WriteString("\n")
+			return
+		case atom.Pre:
+			b.WriteString(codeFence(n) + "\n\n")
+			return
+		case atom.Ul:
+			writeList(b, n, false)
+			return
+		case atom.Ol:
+			writeList(b, n, true)
+			return
+		case atom.Table:
+			b.WriteString(table(n) + "\n\n")
+			return
+		}
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		writeBlock(b, c)
+	}
+}
+
+func writeList(b *strings.Builder, n *html.Node, ordered bool) {
+	i := 1
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type != html.ElementNode || c.DataAtom != atom.Li {
+			continue
+		}
+		marker := "- "
+		if ordered {
+			marker = fmt.Sprintf("%d. ", i)
+			i++
+		}
+		b.WriteString(marker + inlineText(c) + "\n")
+	}
+	b.WriteString("\n")
+}
+
+func codeFence(pre *html.Node) string {
+	lang := ""
+	code := pre
+	if c := firstChildAtom(pre, atom.Code); c != nil {
+		code = c
+		lang = codeLanguage(c)
+	}
+	return "```" + lang + "\n" + strings.TrimRight(rawText(code), "\n") + "\n```"
+}
+
+func codeLanguage(code *html.Node) string {
+	for _, attr := range code.Attr {
+		if attr.Key != "class" {
+			continue
+		}
+		for _, class := range strings.Fields(attr.Val) {
+			if strings.HasPrefix(class, "language-") {
+				return strings.TrimPrefix(class, "language-")
+			}
+		}
+	}
+	return ""
+}
+
+func table(t *html.Node) string {
+	var rows [][]string
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.ElementNode && n.DataAtom == atom.Tr {
+			var row []string
+			for c := n.FirstChild; c != nil; c = c.NextSibling {
+				if c.Type == html.ElementNode && (c.DataAtom == atom.Td || c.DataAtom == atom.Th) {
+					row = append(row, inlineText(c))
+				}
+			}
+			rows = append(rows, row)
+			return
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(t)
+
+	if len(rows) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString("| " + strings.Join(rows[0], " | ") + " |\n")
+	b.WriteString("|" + strings.Repeat(" --- |", len(rows[0])) + "\n")
+	for _, row := range rows[1:] {
+		b.WriteString("| " + strings.Join(row, " | ") + " |\n")
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// inlineText renders n's descendants with inline formatting unwrapped
+// (strong/em/code/a), collapsing whitespace to single spaces.
+func inlineText(n *html.Node) string {
+	var b strings.Builder
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		switch n.Type {
+		case html.TextNode:
+			b.WriteString(collapseSpace(n.Data))
+			return
+		case html.ElementNode:
+			switch n.DataAtom {
+			case atom.Strong, atom.B:
+				b.WriteString("**")
+				writeChildren(&b, n, walk)
+				b.WriteString("**")
+				return
+			case atom.Em, atom.I:
+				b.WriteString("*")
+				writeChildren(&b, n, walk)
+				b.WriteString("*")
+				return
+			case atom.Code:
+				b.WriteString("`" + rawText(n) + "`")
+				return
+			case atom.A:
+				href := attr(n, "href")
+				b.WriteString("[")
+				writeChildren(&b, n, walk)
+				b.WriteString("](" + href + ")")
+				return
+			case atom.Br:
+				b.WriteString("\n")
+				return
+			}
+		}
+		writeChildren(&b, n, walk)
+	}
+	walk(n)
+	return strings.TrimSpace(collapseSpace(b.String()))
+}
+
+func writeChildren(b *strings.Builder, n *html.Node, walk func(*html.Node)) {
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		walk(c)
+	}
+}
+
+func writeText(b *strings.Builder, n *html.Node) {
+	if n.Type == html.TextNode {
+		b.WriteString(n.Data)
+		return
+	}
+	if n.Type == html.ElementNode {
+		switch n.DataAtom {
+		case atom.P, atom.Div, atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6, atom.Li, atom.Tr:
+			defer b.WriteString("\n")
+		}
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		writeText(b, c)
+	}
+}
+
+func rawText(n *html.Node) string {
+	var b strings.Builder
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.TextNode {
+			b.WriteString(n.Data)
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(n)
+	return b.String()
+}
+
+func firstChildAtom(n *html.Node, a atom.Atom) *html.Node {
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type == html.ElementNode && c.DataAtom == a {
+			return c
+		}
+	}
+	return nil
+}
+
+func attr(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return a.Val
+		}
+	}
+	return ""
+}
+
+func collapseSpace(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func collapseBlankLines(s string) string {
+	lines := strings.Split(s, "\n")
+	var out []string
+	blank := false
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			if blank {
+				continue
+			}
+			blank = true
+		} else {
+			blank = false
+		}
+		out = append(out, line)
+	}
+	return strings.Join(out, "\n")
+}
@@ -0,0 +1,47 @@
+package readable
+
+import (
+	"strings"
+	"testing"
+)
+
+const codeFixture = `<article>
+<h2>Example</h2>
+<pre><code class="language-go">func main() {
+	fmt.Println("hi")
+}</code></pre>
+</article>`
+
+func TestToMarkdown_CodeFenceUsesLanguageClass(t *testing.T) {
+	a, err := Extract(codeFixture)
+	if err != nil {
+		t.Fatalf("Extract: %v", err)
+	}
+
+	md := ToMarkdown(a)
+	if !strings.Contains(md, "```go\n") {
+		t.Errorf("ToMarkdown missing ```go fence, got:\n%s", md)
+	}
+	if !strings.Contains(md, `fmt.Println("hi")`) {
+		t.Errorf("ToMarkdown missing code body, got:\n%s", md)
+	}
+}
+
+func TestCodeLanguage(t *testing.T) {
+	cases := []struct {
+		class string
+		want  string
+	}{
+		{`language-go`, "go"},
+		{`hljs language-python`, "python"},
+		{`highlight`, ""},
+		{``, ""},
+	}
+	for _, c := range cases {
+		doc := mustParse(t, `<pre><code class="`+c.class+`">x</code></pre>`)
+		code := find(doc, "code")
+		if got := codeLanguage(code); got != c.want {
+			t.Errorf("codeLanguage(class=%q) = %q, want %q", c.class, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,169 @@
+// Package readable extracts the main content from an HTML page (in the
+// spirit of Mozilla's Readability) and serializes it to Markdown or
+// plain text, so tools that read web pages spend tokens on the article
+// instead of navigation chrome and boilerplate.
+package readable
+
+import (
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// tagWeight biases candidate scoring toward elements that typically hold
+// article content and away from elements that typically don't.
+var tagWeight = map[atom.Atom]int{
+	atom.Article:    25,
+	atom.Section:    10,
+	atom.Div:        5,
+	atom.P:          5,
+	atom.Pre:        3,
+	atom.Td:         3,
+	atom.Blockquote: 3,
+	atom.Form:       -10,
+}
+
+// skipTags are dropped entirely before scoring and serialization: they
+// never contribute readable content. This includes boilerplate chrome
+// (nav/aside/header/footer) in addition to non-content tags, so it never
+// leaks into the extracted article regardless of how it scores.
+var skipTags = map[atom.Atom]bool{
+	atom.Script:   true,
+	atom.Style:    true,
+	atom.Noscript: true,
+	atom.Svg:      true,
+	atom.Iframe:   true,
+	atom.Nav:      true,
+	atom.Aside:    true,
+	atom.Header:   true,
+	atom.Footer:   true,
+}
+
+// rootTags are structural containers that wrap the whole document. They
+// score high on raw text volume simply because everything nests under
+// them, which would otherwise beat a genuine nested <article>/<section>.
+// They're excluded from scoring so a real content subtree can win.
+var rootTags = map[atom.Atom]bool{
+	atom.Html: true,
+	atom.Head: true,
+	atom.Body: true,
+}
+
+// Article is the highest-scoring content subtree extracted from a page.
+type Article struct {
+	Title string
+	Root  *html.Node
+}
+
+// Extract parses rawHTML and returns the subtree judged most likely to
+// be the article body.
+func Extract(rawHTML string) (*Article, error) {
+	doc, err := html.Parse(strings.NewReader(rawHTML))
+	if err != nil {
+		return nil, err
+	}
+
+	stripSkipped(doc)
+
+	candidate := bestCandidate(doc)
+	if candidate == nil {
+		candidate = doc
+	}
+
+	return &Article{Title: findTitle(doc), Root: candidate}, nil
+}
+
+// stripSkipped removes script/style/svg/iframe and boilerplate chrome
+// (nav/aside/header/footer) from the tree in place so neither scoring nor
+// serialization ever sees them.
+func stripSkipped(n *html.Node) {
+	var next *html.Node
+	for c := n.FirstChild; c != nil; c = next {
+		next = c.NextSibling
+		if c.Type == html.ElementNode && skipTags[c.DataAtom] {
+			n.RemoveChild(c)
+			continue
+		}
+		stripSkipped(c)
+	}
+}
+
+func findTitle(doc *html.Node) string {
+	var title string
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.ElementNode && n.DataAtom == atom.Title && n.FirstChild != nil {
+			title = strings.TrimSpace(n.FirstChild.Data)
+			return
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			if title != "" {
+				return
+			}
+			walk(c)
+		}
+	}
+	walk(doc)
+	return title
+}
+
+// bestCandidate scores every element node except the rootTags by text
+// density, link density, and tag weight, and returns the highest scorer.
+func bestCandidate(doc *html.Node) *html.Node {
+	var best *html.Node
+	bestScore := -1.0
+
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.ElementNode && !rootTags[n.DataAtom] {
+			if score := score(n); score > bestScore {
+				bestScore = score
+				best = n
+			}
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(doc)
+	return best
+}
+
+func score(n *html.Node) float64 {
+	text := textLen(n)
+	if text < 25 {
+		return -1
+	}
+	linkText := linkTextLen(n)
+	linkDensity := float64(linkText) / float64(text+1)
+
+	s := float64(text) * (1 - linkDensity)
+	s += float64(tagWeight[n.DataAtom])
+	return s
+}
+
+func textLen(n *html.Node) int {
+	if n.Type == html.TextNode {
+		return len(strings.TrimSpace(n.Data))
+	}
+	if n.Type == html.ElementNode && skipTags[n.DataAtom] {
+		return 0
+	}
+	total := 0
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		total += textLen(c)
+	}
+	return total
+}
+
+func linkTextLen(n *html.Node) int {
+	if n.Type == html.ElementNode && n.DataAtom == atom.A {
+		return textLen(n)
+	}
+	total := 0
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		total += linkTextLen(c)
+	}
+	return total
+}
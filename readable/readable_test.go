@@ -0,0 +1,108 @@
+package readable
+
+import (
+	"strings"
+	"testing"
+
+	"golang.org/x/net/html"
+)
+
+// articleFixture is a trimmed-down capture of a typical blog post: a nav
+// bar, a sidebar, a footer, and a single <article> with real content.
+// The boilerplate chrome has enough text that a naive "most text wins"
+// heuristic would pick the <body> instead of the <article>.
+const articleFixture = `<html>
+<head><title>How Readability Works</title></head>
+<body>
+<header>
+<nav>
+<a href="/">Home</a> <a href="/about">About</a> <a href="/contact">Contact</a>
+<a href="/archive">Archive</a> <a href="/rss">RSS</a> <a href="/login">Log in</a>
+</nav>
+</header>
+<aside>
+<p>Subscribe to our newsletter for weekly updates on web standards and tooling.</p>
+<p>Popular posts: Parsing HTML, Writing a Markdown serializer, Rate limiting webfetch clients.</p>
+</aside>
+<article>
+<h1>How Readability Works</h1>
+<p>Readability-style extraction scores every element in the document by how
+much text it holds, discounting text that lives inside links, since link
+text is usually navigation rather than prose.</p>
+<p>The element with the highest score is assumed to be the main content,
+and everything else -- navigation, sidebars, footers -- is discarded.</p>
+</article>
+<footer>
+<p>&copy; 2026 Example Corp. All rights reserved. Privacy policy. Terms of service.</p>
+</footer>
+</body>
+</html>`
+
+func TestExtract_PrefersArticleOverChrome(t *testing.T) {
+	a, err := Extract(articleFixture)
+	if err != nil {
+		t.Fatalf("Extract: %v", err)
+	}
+	if a.Title != "How Readability Works" {
+		t.Errorf("Title = %q, want %q", a.Title, "How Readability Works")
+	}
+
+	md := ToMarkdown(a)
+	if !strings.Contains(md, "main content") {
+		t.Errorf("ToMarkdown missing article body, got:\n%s", md)
+	}
+	for _, chrome := range []string{"Subscribe to our newsletter", "Privacy policy", "Archive", "RSS"} {
+		if strings.Contains(md, chrome) {
+			t.Errorf("ToMarkdown leaked chrome %q, got:\n%s", chrome, md)
+		}
+	}
+}
+
+func TestScore_PenalizesLinkHeavyText(t *testing.T) {
+	doc := mustParse(t, `<div><p>Real prose that a reader actually wants to see here.</p></div>`)
+	prose := find(doc, "p")
+
+	linky := mustParse(t, `<div><a href="/a">Real prose that a reader actually wants to see here.</a></div>`)
+	link := find(linky, "a")
+
+	if score(link) >= score(prose) {
+		t.Errorf("link-wrapped text scored %v, want less than plain prose's %v", score(link), score(prose))
+	}
+}
+
+func TestScore_ShortTextIsRejected(t *testing.T) {
+	doc := mustParse(t, `<p>Too short</p>`)
+	p := find(doc, "p")
+	if s := score(p); s != -1 {
+		t.Errorf("score(%q) = %v, want -1", "Too short", s)
+	}
+}
+
+func mustParse(t *testing.T, rawHTML string) *html.Node {
+	t.Helper()
+	doc, err := html.Parse(strings.NewReader(rawHTML))
+	if err != nil {
+		t.Fatalf("html.Parse: %v", err)
+	}
+	return doc
+}
+
+// find returns the first element of the given tag name in doc, or nil.
+func find(doc *html.Node, tag string) *html.Node {
+	var found *html.Node
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		if found != nil {
+			return
+		}
+		if n.Type == html.ElementNode && n.Data == tag {
+			found = n
+			return
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(doc)
+	return found
+}
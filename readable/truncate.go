@@ -0,0 +1,23 @@
+package readable
+
+import "strings"
+
+// Truncate shortens markdown to at most maxChars, cutting at the last
+// paragraph boundary that fits so the text ends on a whole paragraph or
+// heading. When no boundary fits, it cuts mid-paragraph. A heading can end
+// up last, without the content under it. maxChars <= 0 disables
+// truncation.
+func Truncate(markdown string, maxChars int) string {
+	if maxChars <= 0 || len(markdown) <= maxChars {
+		return markdown
+	}
+
+	cut := markdown[:maxChars]
+	if i := strings.LastIndex(cut, "\n\n"); i > 0 {
+		cut = cut[:i]
+	} else {
+		// Leave room for the trailing newline added below.
+		cut = cut[:maxChars-1]
+	}
+	return strings.TrimRight(cut, "\n") + "\n"
+}
@@ -0,0 +1,25 @@
+package readable
+
+import "testing"
+
+func TestTruncate(t *testing.T) {
+	md := "# Title\n\nFirst paragraph of real length here.\n\nSecond paragraph that pushes past the cutoff point.\n"
+
+	cases := []struct {
+		name     string
+		maxChars int
+		want     string
+	}{
+		{"disabled", 0, md},
+		{"over length", len(md) + 100, md},
+		{"cuts at paragraph boundary", 55, "# Title\n\nFirst paragraph of real length here.\n"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := Truncate(md, c.maxChars); got != c.want {
+				t.Errorf("Truncate(_, %d) = %q, want %q", c.maxChars, got, c.want)
+			}
+		})
+	}
+}
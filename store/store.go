@@ -0,0 +1,196 @@
+// Package store persists conversations and their turns to a local SQLite
+// database so a session can be listed, resumed, or exported later instead
+// of living only in memory for the lifetime of one TUI run.
+package store
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+const schema = `
+CREATE TABLE IF NOT EXISTS conversations (
+	id         TEXT PRIMARY KEY,
+	title      TEXT NOT NULL,
+	created_at INTEGER NOT NULL,
+	updated_at INTEGER NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS turns (
+	id              TEXT PRIMARY KEY,
+	conversation_id TEXT NOT NULL,
+	parent_id       TEXT NOT NULL,
+	role            TEXT NOT NULL,
+	content         TEXT NOT NULL,
+	tokens          INTEGER NOT NULL DEFAULT 0,
+	created_at      INTEGER NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_turns_conversation ON turns(conversation_id);
+`
+
+// Store is a handle on the SQLite-backed conversation database.
+type Store struct {
+	db *sql.DB
+}
+
+// Open creates (if necessary) and opens the database at path.
+func Open(path string) (*Store, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, fmt.Errorf("store: open %s: %w", path, err)
+	}
+	if _, err := db.Exec(schema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("store: migrate: %w", err)
+	}
+	return &Store{db: db}, nil
+}
+
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// Conversation is a summary row from the conversations table.
+type Conversation struct {
+	ID        string
+	Title     string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Row is a single persisted turn: a user prompt, agent reply, reasoning
+// step, or function call, keyed by conversation and parent turn.
+type Row struct {
+	ID             string
+	ConversationID string
+	ParentID       string
+	Role           string
+	Content        string
+	Tokens         int
+	CreatedAt      time.Time
+}
+
+// NewConversation inserts a conversation row and returns it.
+func (s *Store) NewConversation(id, title string) (Conversation, error) {
+	now := time.Now()
+	c := Conversation{ID: id, Title: title, CreatedAt: now, UpdatedAt: now}
+	_, err := s.db.Exec(
+		`INSERT INTO conversations (id, title, created_at, updated_at) VALUES (?, ?, ?, ?)`,
+		c.ID, c.Title, c.CreatedAt.Unix(), c.UpdatedAt.Unix(),
+	)
+	if err != nil {
+		return Conversation{}, fmt.Errorf("store: new conversation: %w", err)
+	}
+	return c, nil
+}
+
+// ListConversations returns every conversation, most recently updated first.
+func (s *Store) ListConversations() ([]Conversation, error) {
+	rows, err := s.db.Query(`SELECT id, title, created_at, updated_at FROM conversations ORDER BY updated_at DESC`)
+	if err != nil {
+		return nil, fmt.Errorf("store: list conversations: %w", err)
+	}
+	defer rows.Close()
+
+	var out []Conversation
+	for rows.Next() {
+		var c Conversation
+		var createdAt, updatedAt int64
+		if err := rows.Scan(&c.ID, &c.Title, &createdAt, &updatedAt); err != nil {
+			return nil, fmt.Errorf("store: scan conversation: %w", err)
+		}
+		c.CreatedAt = time.Unix(createdAt, 0)
+		c.UpdatedAt = time.Unix(updatedAt, 0)
+		out = append(out, c)
+	}
+	return out, rows.Err()
+}
+
+// Conversation looks up a single conversation by ID.
+func (s *Store) Conversation(id string) (Conversation, error) {
+	var c Conversation
+	var createdAt, updatedAt int64
+	err := s.db.QueryRow(`SELECT id, title, created_at, updated_at FROM conversations WHERE id = ?`, id).
+		Scan(&c.ID, &c.Title, &createdAt, &updatedAt)
+	if err != nil {
+		return Conversation{}, fmt.Errorf("store: conversation %s: %w", id, err)
+	}
+	c.CreatedAt = time.Unix(createdAt, 0)
+	c.UpdatedAt = time.Unix(updatedAt, 0)
+	return c, nil
+}
+
+// DeleteConversation removes a conversation and all of its turns.
+func (s *Store) DeleteConversation(id string) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("store: delete conversation %s: %w", id, err)
+	}
+	if _, err := tx.Exec(`DELETE FROM turns WHERE conversation_id = ?`, id); err != nil {
+		tx.Rollback()
+		return fmt.Errorf("store: delete turns for %s: %w", id, err)
+	}
+	if _, err := tx.Exec(`DELETE FROM conversations WHERE id = ?`, id); err != nil {
+		tx.Rollback()
+		return fmt.Errorf("store: delete conversation %s: %w", id, err)
+	}
+	return tx.Commit()
+}
+
+// SaveTurn inserts a turn row and bumps the parent conversation's
+// updated_at so ListConversations sorts by recent activity.
+func (s *Store) SaveTurn(row Row) error {
+	if row.CreatedAt.IsZero() {
+		row.CreatedAt = time.Now()
+	}
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("store: save turn %s: %w", row.ID, err)
+	}
+	_, err = tx.Exec(
+		`INSERT INTO turns (id, conversation_id, parent_id, role, content, tokens, created_at) VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		row.ID, row.ConversationID, row.ParentID, row.Role, row.Content, row.Tokens, row.CreatedAt.Unix(),
+	)
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("store: save turn %s: %w", row.ID, err)
+	}
+	if _, err := tx.Exec(`UPDATE conversations SET updated_at = ? WHERE id = ?`, row.CreatedAt.Unix(), row.ConversationID); err != nil {
+		tx.Rollback()
+		return fmt.Errorf("store: touch conversation %s: %w", row.ConversationID, err)
+	}
+	return tx.Commit()
+}
+
+// LoadTurns returns every turn for a conversation in insertion order, so
+// the caller can replay them to rebuild the in-memory turn tree.
+// created_at alone isn't enough: it's second-resolution, so a user turn
+// and its streamed answer finalized within the same second tie, and
+// SQLite doesn't break ties on its own. rowid does, and -- since turns
+// are never updated in place -- always agrees with insertion order.
+func (s *Store) LoadTurns(conversationID string) ([]Row, error) {
+	rows, err := s.db.Query(
+		`SELECT id, conversation_id, parent_id, role, content, tokens, created_at FROM turns WHERE conversation_id = ? ORDER BY created_at ASC, rowid ASC`,
+		conversationID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("store: load turns for %s: %w", conversationID, err)
+	}
+	defer rows.Close()
+
+	var out []Row
+	for rows.Next() {
+		var r Row
+		var createdAt int64
+		if err := rows.Scan(&r.ID, &r.ConversationID, &r.ParentID, &r.Role, &r.Content, &r.Tokens, &createdAt); err != nil {
+			return nil, fmt.Errorf("store: scan turn: %w", err)
+		}
+		r.CreatedAt = time.Unix(createdAt, 0)
+		out = append(out, r)
+	}
+	return out, rows.Err()
+}
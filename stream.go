@@ -0,0 +1,63 @@
+package main
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// streamChunkRunes and streamDelay control how finely a finished result is
+// sliced into deltas. orenoagent-go's Ask returns whole results rather
+// than incremental chunks, so this is a local buffering shim: it doesn't
+// make the agent itself faster, but it keeps the viewport from jumping
+// from nothing to a wall of text in one frame.
+const (
+	streamChunkRunes = 24
+	streamDelay      = 15 * time.Millisecond
+)
+
+type answerDelta struct {
+	turnID   string
+	fragment string
+}
+
+type reasoningDelta struct {
+	turnID   string
+	fragment string
+}
+
+type functionCallDelta struct {
+	turnID   string
+	fragment string
+}
+
+type answerDone struct{ turnID string }
+
+type reasoningDone struct{ turnID string }
+
+type functionCallDone struct{ turnID string }
+
+// askDone is sent once an ask cycle's result channel is fully drained, so
+// the UI can stop showing its mid-stream typing indicator. A single ask
+// can stream several results (reasoning, function calls, the final
+// answer) before this fires.
+type askDone struct{}
+
+// streamText slices text into chunks and sends them as delta messages
+// via delta, pacing them with streamDelay, then sends a final done
+// message once the whole result has been delivered.
+func streamText(p *tea.Program, turnID, text string, delta func(turnID, fragment string) tea.Msg, done func(turnID string) tea.Msg) {
+	runes := []rune(text)
+	for i := 0; i < len(runes); i += streamChunkRunes {
+		end := i + streamChunkRunes
+		if end > len(runes) {
+			end = len(runes)
+		}
+		p.Send(delta(turnID, string(runes[i:end])))
+		time.Sleep(streamDelay)
+	}
+	if len(runes) == 0 {
+		p.Send(delta(turnID, ""))
+	}
+	p.Send(done(turnID))
+}
@@ -1,17 +1,22 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"io"
-	"net/http"
 	"time"
 
+	"github.com/demouth/orenoagent-cli/readable"
+	"github.com/demouth/orenoagent-cli/webfetch"
 	"github.com/demouth/orenoagent-go"
 	"github.com/tectiv3/websearch"
 	"github.com/tectiv3/websearch/provider"
 )
 
+// webClient is shared by every WebReader call so rate limiting and the
+// robots.txt cache apply across the whole process, not per-request.
+var webClient = webfetch.New()
+
 var Tools = []orenoagent.Tool{
 	{
 		Name:        "currentTime",
@@ -78,33 +83,61 @@ var Tools = []orenoagent.Tool{
 					"type":        "string",
 					"description": "URL of the page to retrieve",
 				},
+				"format": map[string]string{
+					"type":        "string",
+					"description": "Output format: markdown (default), text, or html",
+				},
+				"max_chars": map[string]string{
+					"type":        "integer",
+					"description": "Truncate output to roughly this many characters, cutting at a paragraph boundary",
+				},
 			},
 			"required": []string{"url"},
 		},
 		Function: func(args string) string {
 			var param struct {
-				Url string
+				Url      string
+				Format   string
+				MaxChars int
 			}
 			err := json.Unmarshal([]byte(args), &param)
 			if err != nil {
 				return fmt.Sprintf("%v", err)
 			}
+			if param.Format == "" {
+				param.Format = "markdown"
+			}
 
-			req, _ := http.NewRequest("GET", param.Url, nil)
-			req.Header.Set("User-Agent", "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/142.0.0.0 Safari/537.36")
-
-			client := &http.Client{}
-			resp, err := client.Do(req)
+			result, err := webClient.Fetch(context.Background(), param.Url)
 			if err != nil {
 				return fmt.Sprintf("%v", err)
 			}
-			defer resp.Body.Close()
-			bodyBytes, err := io.ReadAll(resp.Body)
+
+			if param.Format == "html" {
+				return truncate(result.Body, param.MaxChars)
+			}
+
+			article, err := readable.Extract(result.Body)
 			if err != nil {
 				return fmt.Sprintf("%v", err)
 			}
 
-			return string(bodyBytes)
+			var out string
+			if param.Format == "text" {
+				out = readable.ToText(article)
+			} else {
+				out = readable.ToMarkdown(article)
+			}
+			return readable.Truncate(out, param.MaxChars)
 		},
 	},
 }
+
+// truncate is a plain character cutoff for raw HTML, where
+// readable.Truncate's paragraph-boundary logic doesn't apply.
+func truncate(s string, maxChars int) string {
+	if maxChars <= 0 || len(s) <= maxChars {
+		return s
+	}
+	return s[:maxChars]
+}
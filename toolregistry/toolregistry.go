@@ -0,0 +1,190 @@
+// Package toolregistry aggregates the CLI's built-in tools with tools
+// served by external tool-server processes, and exposes them uniformly
+// as []orenoagent.Tool so the rest of the program doesn't need to know
+// which tools are local and which are remote.
+package toolregistry
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/demouth/orenoagent-cli/toolserver"
+	"github.com/demouth/orenoagent-go"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+const (
+	breakerThreshold = 3
+	breakerCooldown  = 30 * time.Second
+)
+
+// Provider is anything that can describe and invoke a set of tools. The
+// only implementation today is grpcProvider, dialing a --tool-server
+// address, but tests can fake it, and an MCP-backed provider could
+// satisfy the same interface.
+type Provider interface {
+	Describe(ctx context.Context) ([]toolserver.ToolSpec, error)
+	Invoke(ctx context.Context, name, argsJSON string) (string, error)
+}
+
+// Registry aggregates built-in tools with tools fetched from remote
+// providers, applying a per-call timeout and a per-tool circuit breaker
+// so one flaky remote tool server cannot hang or repeatedly stall the
+// agent loop.
+type Registry struct {
+	timeout time.Duration
+
+	mu        sync.Mutex
+	providers []Provider
+	breakers  map[string]*breaker
+}
+
+// New creates an empty registry with the given per-call timeout.
+func New(timeout time.Duration) *Registry {
+	return &Registry{timeout: timeout, breakers: map[string]*breaker{}}
+}
+
+// AddServer registers a remote tool server reachable at addr (host:port).
+func (r *Registry) AddServer(addr string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.providers = append(r.providers, &grpcProvider{addr: addr, timeout: r.timeout})
+}
+
+// Tools returns builtins plus every tool described by the registered
+// providers. A provider that fails to describe itself is logged and
+// skipped rather than failing the whole aggregation.
+func (r *Registry) Tools(ctx context.Context, builtins []orenoagent.Tool) []orenoagent.Tool {
+	tools := append([]orenoagent.Tool{}, builtins...)
+
+	r.mu.Lock()
+	providers := append([]Provider{}, r.providers...)
+	r.mu.Unlock()
+
+	for _, p := range providers {
+		specs, err := p.Describe(ctx)
+		if err != nil {
+			log.Printf("toolregistry: describe failed: %v", err)
+			continue
+		}
+		for _, spec := range specs {
+			spec, p := spec, p
+			tools = append(tools, orenoagent.Tool{
+				Name:        spec.Name,
+				Description: spec.Description,
+				Parameters:  spec.Parameters,
+				Function: func(argsJSON string) string {
+					return r.invoke(ctx, p, spec.Name, argsJSON)
+				},
+			})
+		}
+	}
+	return tools
+}
+
+func (r *Registry) invoke(ctx context.Context, p Provider, name, argsJSON string) string {
+	b := r.breakerFor(name)
+	if !b.allow() {
+		return fmt.Sprintf("tool %q is temporarily unavailable (circuit open)", name)
+	}
+	result, err := p.Invoke(ctx, name, argsJSON)
+	b.record(err)
+	if err != nil {
+		return fmt.Sprintf("%v", err)
+	}
+	return result
+}
+
+func (r *Registry) breakerFor(name string) *breaker {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	b, ok := r.breakers[name]
+	if !ok {
+		b = &breaker{}
+		r.breakers[name] = b
+	}
+	return b
+}
+
+// breaker is a minimal consecutive-failure circuit breaker: after
+// breakerThreshold failures in a row it rejects calls until
+// breakerCooldown has passed.
+type breaker struct {
+	mu        sync.Mutex
+	failures  int
+	openUntil time.Time
+}
+
+func (b *breaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return time.Now().After(b.openUntil)
+}
+
+func (b *breaker) record(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err == nil {
+		b.failures = 0
+		b.openUntil = time.Time{}
+		return
+	}
+	b.failures++
+	if b.failures >= breakerThreshold {
+		b.openUntil = time.Now().Add(breakerCooldown)
+	}
+}
+
+// grpcProvider talks to a tool server over gRPC. Each call dials fresh
+// and bounds itself with the registry's timeout as a context deadline,
+// which gRPC honors natively.
+type grpcProvider struct {
+	addr    string
+	timeout time.Duration
+}
+
+func (p *grpcProvider) Describe(ctx context.Context) ([]toolserver.ToolSpec, error) {
+	client, conn, err := p.dial()
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	reply, err := client.Describe(ctx, &toolserver.DescribeRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("tool server %s: %w", p.addr, err)
+	}
+	return reply.Tools, nil
+}
+
+func (p *grpcProvider) Invoke(ctx context.Context, name, argsJSON string) (string, error) {
+	client, conn, err := p.dial()
+	if err != nil {
+		return "", err
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	reply, err := client.Invoke(ctx, &toolserver.InvokeRequest{Name: name, ArgsJSON: argsJSON})
+	if err != nil {
+		return "", fmt.Errorf("tool server %s: %w", p.addr, err)
+	}
+	return reply.Result, nil
+}
+
+func (p *grpcProvider) dial() (toolserver.ToolServerClient, *grpc.ClientConn, error) {
+	conn, err := grpc.NewClient(p.addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, nil, fmt.Errorf("tool server %s: %w", p.addr, err)
+	}
+	return toolserver.NewToolServerClient(conn), conn, nil
+}
@@ -0,0 +1,26 @@
+package toolserver
+
+import (
+	"encoding/json"
+
+	"google.golang.org/grpc/encoding"
+)
+
+const jsonCodecName = "json"
+
+func init() {
+	encoding.RegisterCodec(jsonCodec{})
+}
+
+// jsonCodec lets ToolSpec, DescribeRequest, etc. travel over gRPC as
+// plain JSON instead of protobuf, so this package doesn't need generated
+// .pb.go types. Every call in this package requests it explicitly via
+// grpc.CallContentSubtype, so registering it here only ever affects
+// ToolServer traffic.
+type jsonCodec struct{}
+
+func (jsonCodec) Marshal(v any) ([]byte, error) { return json.Marshal(v) }
+
+func (jsonCodec) Unmarshal(data []byte, v any) error { return json.Unmarshal(data, v) }
+
+func (jsonCodec) Name() string { return jsonCodecName }
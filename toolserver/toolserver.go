@@ -0,0 +1,145 @@
+// Package toolserver defines the wire contract between orenoagent-cli and
+// out-of-process tool providers, and is shared by the CLI's tool registry
+// client and by tool server implementations such as cmd/tool-server-example.
+//
+// The contract is intentionally small: a tool server describes the tools
+// it offers, then invokes them by name with JSON-encoded arguments. It is
+// exposed over gRPC under the service name "toolserver.ToolServer" (see
+// ServiceName and RegisterToolServerServer), giving deadline propagation
+// and a real RPC framing instead of a hand-rolled net/rpc+gob TCP
+// protocol. This file stands in for the protoc-gen-go/protoc-gen-go-grpc
+// output until a .proto/codegen pipeline is added: the client and server
+// stubs below are hand-written against the same grpc.ServiceDesc shape
+// codegen would produce, and codec.go swaps in a JSON wire codec so the
+// request/reply types can stay plain Go structs in the meantime. A tool
+// server backed by MCP instead of gRPC can implement the same
+// toolregistry.Provider interface without touching this contract.
+package toolserver
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+)
+
+// ServiceName is the fully qualified gRPC service name every tool server
+// registers under.
+const ServiceName = "toolserver.ToolServer"
+
+// ToolSpec describes one tool a server offers, mirroring the shape of
+// orenoagent.Tool minus its Function, which only exists locally.
+// Parameters travels as JSON (via codec.go's jsonCodec), so arbitrary
+// map[string]any schema values round-trip without the gob-registration
+// gotcha a gob-encoded interface{} map would otherwise hit; see
+// cmd/tool-server-example's "echo" tool for a server that actually
+// returns one.
+type ToolSpec struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	Parameters  map[string]any `json:"parameters,omitempty"`
+}
+
+type DescribeRequest struct{}
+
+type DescribeResponse struct {
+	Tools []ToolSpec `json:"tools"`
+}
+
+type InvokeRequest struct {
+	Name     string `json:"name"`
+	ArgsJSON string `json:"args_json"`
+}
+
+type InvokeResponse struct {
+	Result string `json:"result"`
+}
+
+// ToolServerServer is implemented by tool server processes, e.g.
+// cmd/tool-server-example, and registered with a *grpc.Server via
+// RegisterToolServerServer.
+type ToolServerServer interface {
+	Describe(context.Context, *DescribeRequest) (*DescribeResponse, error)
+	Invoke(context.Context, *InvokeRequest) (*InvokeResponse, error)
+}
+
+// RegisterToolServerServer registers srv with s under ServiceName.
+func RegisterToolServerServer(s grpc.ServiceRegistrar, srv ToolServerServer) {
+	s.RegisterService(&serviceDesc, srv)
+}
+
+// ToolServerClient is the client half of the contract. Obtain one with
+// NewToolServerClient over an established *grpc.ClientConn.
+type ToolServerClient interface {
+	Describe(ctx context.Context, in *DescribeRequest, opts ...grpc.CallOption) (*DescribeResponse, error)
+	Invoke(ctx context.Context, in *InvokeRequest, opts ...grpc.CallOption) (*InvokeResponse, error)
+}
+
+type toolServerClient struct {
+	cc grpc.ClientConnInterface
+}
+
+// NewToolServerClient wraps an established gRPC connection as a
+// ToolServerClient.
+func NewToolServerClient(cc grpc.ClientConnInterface) ToolServerClient {
+	return &toolServerClient{cc: cc}
+}
+
+func (c *toolServerClient) Describe(ctx context.Context, in *DescribeRequest, opts ...grpc.CallOption) (*DescribeResponse, error) {
+	out := new(DescribeResponse)
+	if err := c.cc.Invoke(ctx, "/"+ServiceName+"/Describe", in, out, withJSONCodec(opts)...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *toolServerClient) Invoke(ctx context.Context, in *InvokeRequest, opts ...grpc.CallOption) (*InvokeResponse, error) {
+	out := new(InvokeResponse)
+	if err := c.cc.Invoke(ctx, "/"+ServiceName+"/Invoke", in, out, withJSONCodec(opts)...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func withJSONCodec(opts []grpc.CallOption) []grpc.CallOption {
+	return append(opts, grpc.CallContentSubtype(jsonCodecName))
+}
+
+var serviceDesc = grpc.ServiceDesc{
+	ServiceName: ServiceName,
+	HandlerType: (*ToolServerServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{MethodName: "Describe", Handler: describeHandler},
+		{MethodName: "Invoke", Handler: invokeHandler},
+	},
+	Metadata: "toolserver.proto",
+}
+
+func describeHandler(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+	in := new(DescribeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ToolServerServer).Describe(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/" + ServiceName + "/Describe"}
+	handler := func(ctx context.Context, req any) (any, error) {
+		return srv.(ToolServerServer).Describe(ctx, req.(*DescribeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func invokeHandler(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+	in := new(InvokeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ToolServerServer).Invoke(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/" + ServiceName + "/Invoke"}
+	handler := func(ctx context.Context, req any) (any, error) {
+		return srv.(ToolServerServer).Invoke(ctx, req.(*InvokeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
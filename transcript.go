@@ -0,0 +1,23 @@
+package main
+
+import (
+	"os"
+	"strings"
+)
+
+// saveTranscript writes the rendered message history to path, with a
+// blank line between messages.
+func saveTranscript(path string, messages []string) error {
+	return os.WriteFile(path, []byte(strings.Join(messages, "\n\n")), 0o644)
+}
+
+// loadTranscript reads a transcript previously written by saveTranscript
+// and returns it as individual messages, splitting on blank lines; a
+// message that itself contains a blank line comes back as several.
+func loadTranscript(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return strings.Split(string(data), "\n\n"), nil
+}
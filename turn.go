@@ -0,0 +1,159 @@
+package main
+
+import (
+	"strconv"
+	"sync/atomic"
+	"time"
+)
+
+// Turn is a single node in the conversation tree: one user prompt, agent
+// reply, reasoning step, or function call. Conversations branch when the
+// user edits or re-issues a prompt from an earlier point, so ParentID
+// and Children form a tree rather than a flat transcript.
+type Turn struct {
+	ID       string
+	ParentID string
+	Role     string // "user", "agent", "reasoning", "function", or "raw" (a /load line)
+	Content  string
+	Children []*Turn
+}
+
+var turnSeq int64
+
+// nextTurnID returns a turn identifier that is unique across runs, so it
+// never collides with the IDs of turns replayed from the store.
+func nextTurnID() string {
+	return "t" + strconv.FormatInt(time.Now().UnixNano(), 36) + "-" + strconv.FormatInt(atomic.AddInt64(&turnSeq, 1), 10)
+}
+
+// styled renders the turn using the same per-role styles the flat message
+// list used before turns existed.
+func (t *Turn) styled() string {
+	switch t.Role {
+	case "user":
+		return userMessage{message: t.Content}.Content()
+	case "agent":
+		return answerMessage{message: t.Content}.Content()
+	case "reasoning":
+		return reasoningMessage{message: t.Content}.Content()
+	case "function":
+		return functionCallMessage{message: t.Content}.Content()
+	default:
+		return t.Content
+	}
+}
+
+// addTurn appends a new child turn under parent and indexes it by ID.
+func (m *model) addTurn(parent *Turn, role, content string) *Turn {
+	t := &Turn{ID: nextTurnID(), ParentID: parent.ID, Role: role, Content: content}
+	parent.Children = append(parent.Children, t)
+	m.turns[t.ID] = t
+	persistTurn(t)
+	return t
+}
+
+// note appends an informational turn under the active turn, used for
+// command feedback (errors, confirmations) shown inline in the tree.
+func (m *model) note(message string) {
+	t := m.addTurn(m.active, "function", message)
+	m.active = t
+	m.askTail = t
+}
+
+// ensureTurn returns the turn indexed under id, creating it as a new
+// child of parent on first use. Later calls with the same id (later
+// deltas of the same streaming result) just return the existing turn.
+func (m *model) ensureTurn(id string, parent *Turn, role string) *Turn {
+	if t, ok := m.turns[id]; ok {
+		return t
+	}
+	t := &Turn{ID: id, ParentID: parent.ID, Role: role}
+	parent.Children = append(parent.Children, t)
+	m.turns[t.ID] = t
+	return t
+}
+
+// finalizeTurn marks a streamed turn complete: it becomes the new
+// askTail so the next result in this ask cycle (or the next user turn)
+// attaches after it, and its final content is persisted.
+func (m *model) finalizeTurn(id string) {
+	t, ok := m.turns[id]
+	if !ok {
+		return
+	}
+	m.active = t
+	m.askTail = t
+	persistTurn(t)
+}
+
+// parentOf returns t's parent, or the synthetic root if t has none.
+func (m *model) parentOf(t *Turn) *Turn {
+	if p, ok := m.turns[t.ParentID]; ok {
+		return p
+	}
+	return m.root
+}
+
+// pathTo walks from the root down to t, returning the turns in order.
+// The synthetic root itself is excluded.
+func (m *model) pathTo(t *Turn) []*Turn {
+	var path []*Turn
+	for t != nil && t.ID != "" {
+		path = append([]*Turn{t}, path...)
+		t = m.turns[t.ParentID]
+	}
+	return path
+}
+
+// siblingIndex returns t's position among its parent's children and the
+// total number of siblings, for rendering a "[i/n]" header.
+func (m *model) siblingIndex(t *Turn) (index, total int) {
+	siblings := m.parentOf(t).Children
+	for i, s := range siblings {
+		if s == t {
+			return i, len(siblings)
+		}
+	}
+	return 0, len(siblings)
+}
+
+// moveToSibling switches the active turn to the previous (delta -1) or
+// next (delta 1) sibling's tip, letting the user walk between branches
+// that fork from the same parent.
+func (m *model) moveToSibling(delta int) {
+	siblings := m.parentOf(m.active).Children
+	if len(siblings) < 2 {
+		return
+	}
+	index, _ := m.siblingIndex(m.active)
+	index = (index + delta + len(siblings)) % len(siblings)
+	m.active = tip(siblings[index])
+}
+
+// tip follows the last child at each level to find the deepest, most
+// recent turn in t's branch.
+func tip(t *Turn) *Turn {
+	for len(t.Children) > 0 {
+		t = t.Children[len(t.Children)-1]
+	}
+	return t
+}
+
+// contextFor renders the ancestor path of t as a plain-text transcript
+// suitable for seeding the agent with prior context along this branch.
+func (m *model) contextFor(t *Turn) string {
+	var s string
+	for _, turn := range m.pathTo(t) {
+		role := turn.Role
+		if role == "user" {
+			role = "User"
+		} else {
+			role = "Assistant"
+		}
+		if turn.Role == "reasoning" || turn.Role == "function" {
+			continue
+		}
+		s += role + ": " + turn.Content + "\n"
+	}
+	return s
+}
@@ -0,0 +1,180 @@
+// Package useragent picks a User-Agent string weighted by real-world
+// browser usage share, so tools that fetch web pages don't announce
+// themselves with one hard-coded, easily-fingerprinted string.
+package useragent
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// caniuseDataURL serves the same "fulldata" usage-share table the
+// caniuse.com site itself is built from.
+const caniuseDataURL = "https://raw.githubusercontent.com/Fyrd/caniuse/main/fulldata-json/data-2.0.json"
+
+const cacheTTL = 24 * time.Hour
+
+// family is a browser engine family with a template for rendering a
+// plausible full User-Agent string and the share of global usage it
+// should be picked with.
+type family struct {
+	name    string
+	weight  float64
+	render  func(platform, version string) string
+	version string
+}
+
+var platforms = []string{
+	"Windows NT 10.0; Win64; x64",
+	"Macintosh; Intel Mac OS X 10_15_7",
+	"X11; Linux x86_64",
+}
+
+// fallback is used when the caniuse data can't be fetched (offline,
+// rate-limited, schema change) so the manager always has something to
+// pick from.
+var fallback = []family{
+	{name: "chrome", weight: 65, version: "124.0.0.0", render: func(platform, version string) string {
+		return fmt.Sprintf("Mozilla/5.0 (%s) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/%s Safari/537.36", platform, version)
+	}},
+	{name: "safari", weight: 18, version: "17.4", render: func(_, version string) string {
+		return fmt.Sprintf("Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/%s Safari/605.1.15", version)
+	}},
+	{name: "firefox", weight: 17, version: "125.0", render: func(platform, version string) string {
+		return fmt.Sprintf("Mozilla/5.0 (%s; rv:%s) Gecko/20100101 Firefox/%s", platform, version, version)
+	}},
+}
+
+// caniuseData is the subset of the fulldata-json schema needed to derive
+// per-family usage share.
+type caniuseData struct {
+	Agents map[string]struct {
+		UsageGlobal map[string]float64 `json:"usage_global"`
+	} `json:"agents"`
+}
+
+// Manager caches a weighted family list and hands out a random UA string
+// consistent with that weighting.
+type Manager struct {
+	httpClient *http.Client
+
+	mu         sync.Mutex
+	families   []family
+	fetchedAt  time.Time
+	refreshing bool
+}
+
+// NewManager returns a Manager seeded with the fallback weights; the
+// first Pick call triggers a background refresh from caniuse.
+func NewManager() *Manager {
+	return &Manager{
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+		families:   fallback,
+	}
+}
+
+// Pick returns a full User-Agent string, weighted-randomly selected
+// across browser families and platform tokens.
+func (m *Manager) Pick() string {
+	m.refreshIfStale()
+
+	m.mu.Lock()
+	families := m.families
+	m.mu.Unlock()
+
+	f := weightedPick(families)
+	platform := platforms[rand.Intn(len(platforms))]
+	return f.render(platform, f.version)
+}
+
+// refreshIfStale kicks off a background refresh when the cached families
+// are stale, but never blocks the caller: Pick always serves whatever is
+// already cached (fallback, or the last successful fetch) while the
+// refresh runs. At most one refresh runs at a time.
+func (m *Manager) refreshIfStale() {
+	m.mu.Lock()
+	stale := time.Since(m.fetchedAt) > cacheTTL
+	if !stale || m.refreshing {
+		m.mu.Unlock()
+		return
+	}
+	m.refreshing = true
+	m.mu.Unlock()
+
+	go m.refresh()
+}
+
+func (m *Manager) refresh() {
+	families, err := m.fetch()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.refreshing = false
+	if err != nil {
+		// Keep whatever we had (fallback or a previous successful
+		// fetch); just push the retry out so we don't hammer the
+		// endpoint on every call while it's failing.
+		m.fetchedAt = time.Now()
+		return
+	}
+	m.families = families
+	m.fetchedAt = time.Now()
+}
+
+func (m *Manager) fetch() ([]family, error) {
+	resp, err := m.httpClient.Get(caniuseDataURL)
+	if err != nil {
+		return nil, fmt.Errorf("useragent: fetch share data: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("useragent: fetch share data: status %d", resp.StatusCode)
+	}
+
+	var data caniuseData
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, fmt.Errorf("useragent: decode share data: %w", err)
+	}
+
+	shares := map[string]float64{"chrome": 0, "safari": 0, "firefox": 0}
+	for agentKey, agent := range data.Agents {
+		family, ok := map[string]string{"chrome": "chrome", "safari": "safari", "and_chr": "chrome", "firefox": "firefox"}[agentKey]
+		if !ok {
+			continue
+		}
+		for _, share := range agent.UsageGlobal {
+			shares[family] += share
+		}
+	}
+
+	out := make([]family, 0, len(fallback))
+	for _, f := range fallback {
+		if share := shares[f.name]; share > 0 {
+			f.weight = share
+		}
+		out = append(out, f)
+	}
+	return out, nil
+}
+
+func weightedPick(families []family) family {
+	var total float64
+	for _, f := range families {
+		total += f.weight
+	}
+	if total <= 0 {
+		return families[0]
+	}
+	r := rand.Float64() * total
+	for _, f := range families {
+		r -= f.weight
+		if r <= 0 {
+			return f
+		}
+	}
+	return families[len(families)-1]
+}
@@ -0,0 +1,112 @@
+package webfetch
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// robotsRules is the small subset of robots.txt this client understands:
+// the Disallow prefixes that apply to every user-agent ("User-agent: *").
+// Anything more specific (per-agent groups, Allow overrides, crawl-delay)
+// is out of scope for a tool that just wants to be polite.
+type robotsRules struct {
+	disallow []string
+}
+
+func (r *robotsRules) allows(path string) bool {
+	for _, prefix := range r.disallow {
+		if prefix != "" && strings.HasPrefix(path, prefix) {
+			return false
+		}
+	}
+	return true
+}
+
+// robotsAllow fetches (and caches) robots.txt for u's host and reports
+// whether u's path may be fetched. A robots.txt that can't be retrieved
+// is treated as permissive, since most sites don't publish one at all.
+func (c *Client) robotsAllow(ctx context.Context, u *url.URL) (bool, error) {
+	rules, err := c.robotsFor(ctx, u)
+	if err != nil {
+		return true, nil
+	}
+	path := u.Path
+	if path == "" {
+		path = "/"
+	}
+	return rules.allows(path), nil
+}
+
+func (c *Client) robotsFor(ctx context.Context, u *url.URL) (*robotsRules, error) {
+	c.mu.Lock()
+	rules, ok := c.robots[u.Host]
+	c.mu.Unlock()
+	if ok {
+		return rules, nil
+	}
+
+	robotsURL := fmt.Sprintf("%s://%s/robots.txt", u.Scheme, u.Host)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, robotsURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", c.ua.Pick())
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		c.cacheRobots(u.Host, &robotsRules{})
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		// Most hosts don't publish a robots.txt at all, so a non-200 is
+		// the common case, not an error worth retrying on every call:
+		// cache the permissive result just like a successful fetch.
+		c.cacheRobots(u.Host, &robotsRules{})
+		return nil, fmt.Errorf("webfetch: robots.txt status %d", resp.StatusCode)
+	}
+
+	rules = parseRobots(resp.Body)
+	c.cacheRobots(u.Host, rules)
+	return rules, nil
+}
+
+func (c *Client) cacheRobots(host string, rules *robotsRules) {
+	c.mu.Lock()
+	c.robots[host] = rules
+	c.mu.Unlock()
+}
+
+// parseRobots extracts the Disallow rules from the "User-agent: *" group(s).
+func parseRobots(r io.Reader) *robotsRules {
+	rules := &robotsRules{}
+	scanner := bufio.NewScanner(r)
+	inWildcardGroup := false
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = strings.TrimSpace(value)
+
+		switch key {
+		case "user-agent":
+			inWildcardGroup = value == "*"
+		case "disallow":
+			if inWildcardGroup && value != "" {
+				rules.disallow = append(rules.disallow, value)
+			}
+		}
+	}
+	return rules
+}
@@ -0,0 +1,165 @@
+// Package webfetch is a polite HTTP client for tools that read arbitrary
+// URLs: it rotates a realistic User-Agent, rate-limits per host, honors
+// robots.txt, caps redirects, and bounds response size so a single fetch
+// can't balloon the tool's output or hang the agent loop.
+package webfetch
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/demouth/orenoagent-cli/useragent"
+	"golang.org/x/time/rate"
+)
+
+const (
+	defaultMaxRedirects     = 5
+	defaultMaxResponseBytes = 2 << 20 // 2 MB
+	defaultRatePerSecond    = 1.0
+)
+
+// Result is what a fetch produced, after size and content-type limits
+// have already been applied.
+type Result struct {
+	URL         string
+	ContentType string
+	Body        string
+	Truncated   bool
+}
+
+// Client is a reusable, polite HTTP fetcher. The zero value is not
+// ready to use; call New.
+type Client struct {
+	HTTPClient       *http.Client
+	MaxRedirects     int
+	MaxResponseBytes int64
+	RatePerSecond    float64
+
+	ua *useragent.Manager
+
+	mu       sync.Mutex
+	limiters map[string]*rate.Limiter
+	robots   map[string]*robotsRules
+}
+
+// New returns a Client with the package defaults: 5 redirects, a 2 MB
+// response cap, and 1 request/sec per host.
+func New() *Client {
+	c := &Client{
+		MaxRedirects:     defaultMaxRedirects,
+		MaxResponseBytes: defaultMaxResponseBytes,
+		RatePerSecond:    defaultRatePerSecond,
+		ua:               useragent.NewManager(),
+		limiters:         map[string]*rate.Limiter{},
+		robots:           map[string]*robotsRules{},
+	}
+	c.HTTPClient = &http.Client{
+		Timeout: 30 * time.Second,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= c.MaxRedirects {
+				return fmt.Errorf("webfetch: stopped after %d redirects", c.MaxRedirects)
+			}
+			return nil
+		},
+	}
+	return c
+}
+
+// Fetch retrieves rawURL, honoring robots.txt and the client's rate,
+// redirect, and size limits. Non-text responses are summarized rather
+// than returned as raw bytes.
+func (c *Client) Fetch(ctx context.Context, rawURL string) (*Result, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("webfetch: parse %q: %w", rawURL, err)
+	}
+
+	allowed, err := c.robotsAllow(ctx, u)
+	if err != nil {
+		return nil, err
+	}
+	if !allowed {
+		return nil, fmt.Errorf("webfetch: %s disallowed by robots.txt", rawURL)
+	}
+
+	if err := c.limiterFor(u.Host).Wait(ctx); err != nil {
+		return nil, fmt.Errorf("webfetch: rate limit wait: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("webfetch: build request: %w", err)
+	}
+	req.Header.Set("User-Agent", c.ua.Pick())
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("webfetch: fetch %s: %w", rawURL, err)
+	}
+	defer resp.Body.Close()
+
+	return c.readResult(rawURL, resp)
+}
+
+func (c *Client) readResult(rawURL string, resp *http.Response) (*Result, error) {
+	limited := io.LimitReader(resp.Body, c.MaxResponseBytes+1)
+	body, err := io.ReadAll(limited)
+	if err != nil {
+		return nil, fmt.Errorf("webfetch: read %s: %w", rawURL, err)
+	}
+
+	truncated := int64(len(body)) > c.MaxResponseBytes
+	if truncated {
+		body = body[:c.MaxResponseBytes]
+	}
+
+	contentType := resp.Header.Get("Content-Type")
+	if contentType == "" {
+		contentType = http.DetectContentType(body)
+	}
+
+	if !isTextual(contentType) {
+		return &Result{
+			URL:         rawURL,
+			ContentType: contentType,
+			Body:        fmt.Sprintf("binary response (%s, %d bytes) - not shown", contentType, len(body)),
+			Truncated:   truncated,
+		}, nil
+	}
+
+	return &Result{
+		URL:         rawURL,
+		ContentType: contentType,
+		Body:        string(body),
+		Truncated:   truncated,
+	}, nil
+}
+
+func isTextual(contentType string) bool {
+	mediaType := strings.ToLower(strings.SplitN(contentType, ";", 2)[0])
+	if strings.HasPrefix(mediaType, "text/") {
+		return true
+	}
+	switch mediaType {
+	case "application/json", "application/xml", "application/xhtml+xml", "application/javascript":
+		return true
+	}
+	return false
+}
+
+func (c *Client) limiterFor(host string) *rate.Limiter {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	l, ok := c.limiters[host]
+	if !ok {
+		l = rate.NewLimiter(rate.Limit(c.RatePerSecond), 1)
+		c.limiters[host] = l
+	}
+	return l
+}